@@ -0,0 +1,35 @@
+// Package airport reads the OurAirports-schema lookup CSV and formats
+// individual airports for substitution into an itinerary.
+package airport
+
+import (
+	"strings"
+	"text/template"
+)
+
+// Airport is one row of the airport lookup CSV.
+type Airport struct {
+	Name         string
+	Municipality string
+	ISOCountry   string
+	IATA         string
+	ICAO         string
+}
+
+// Render formats the airport using tmpl, or just its Name if tmpl is nil.
+func (a Airport) Render(tmpl *template.Template) (string, error) {
+	if tmpl == nil {
+		return a.Name, nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, a); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ParseTemplate compiles a --template value (e.g.
+// "{{.Name}} ({{.IATA}}), {{.Municipality}}") against the Airport fields.
+func ParseTemplate(s string) (*template.Template, error) {
+	return template.New("airport").Parse(s)
+}
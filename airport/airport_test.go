@@ -0,0 +1,36 @@
+package airport
+
+import "testing"
+
+func TestRenderWithoutTemplateUsesName(t *testing.T) {
+	a := Airport{Name: "Los Angeles Intl", IATA: "LAX"}
+	got, err := a.Render(nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got != "Los Angeles Intl" {
+		t.Errorf("got %q, want the airport's Name", got)
+	}
+}
+
+func TestRenderWithTemplateSubstitutesFields(t *testing.T) {
+	tmpl, err := ParseTemplate("{{.Name}} ({{.IATA}}), {{.Municipality}}")
+	if err != nil {
+		t.Fatalf("ParseTemplate: %v", err)
+	}
+	a := Airport{Name: "Los Angeles Intl", IATA: "LAX", Municipality: "Los Angeles"}
+	got, err := a.Render(tmpl)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := "Los Angeles Intl (LAX), Los Angeles"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseTemplateRejectsInvalidSyntax(t *testing.T) {
+	if _, err := ParseTemplate("{{.Name"); err == nil {
+		t.Error("expected an error for unterminated template syntax")
+	}
+}
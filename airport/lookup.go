@@ -0,0 +1,95 @@
+package airport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Lookup holds airports keyed separately by IATA and ICAO code, so a code
+// that's one airport's IATA value and another airport's ICAO value (a
+// real collision in the OurAirports data) resolves to the right one.
+type Lookup struct {
+	ByIATA map[string]Airport
+	ByICAO map[string]Airport
+}
+
+// Resolve returns the airport registered for code under the given
+// marker ("#" for IATA, "##" for ICAO).
+func (l Lookup) Resolve(code, marker string) (Airport, bool) {
+	if marker == "##" {
+		a, ok := l.ByICAO[code]
+		return a, ok
+	}
+	a, ok := l.ByIATA[code]
+	return a, ok
+}
+
+// ReadLookup reads an OurAirports-schema CSV of airports, keeping name,
+// municipality, country and both code columns.
+func ReadLookup(filepath string) (Lookup, error) {
+	lookup := Lookup{ByIATA: make(map[string]Airport), ByICAO: make(map[string]Airport)}
+
+	file, err := os.Open(filepath)
+	if err != nil {
+		return Lookup{}, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return Lookup{}, err
+	}
+
+	col := make(map[string]int, len(header))
+	for i, c := range header {
+		col[strings.ToLower(strings.TrimSpace(c))] = i
+	}
+
+	var missingColumns []string
+	for _, name := range []string{"name", "iata_code", "icao_code"} {
+		if _, ok := col[name]; !ok {
+			missingColumns = append(missingColumns, name)
+		}
+	}
+	if len(missingColumns) > 0 {
+		return Lookup{}, fmt.Errorf("airport lookup malformed. %s", strings.Join(missingColumns, ", "))
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := col[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			break
+		}
+
+		name := field(record, "name")
+		iata := field(record, "iata_code")
+		icao := field(record, "icao_code")
+		if iata == "" || icao == "" || name == "" {
+			return Lookup{}, fmt.Errorf("airport lookup malformed")
+		}
+
+		a := Airport{
+			Name:         name,
+			Municipality: field(record, "municipality"),
+			ISOCountry:   field(record, "iso_country"),
+			IATA:         iata,
+			ICAO:         icao,
+		}
+		lookup.ByIATA[iata] = a
+		lookup.ByICAO[icao] = a
+	}
+
+	return lookup, nil
+}
@@ -0,0 +1,54 @@
+package airport
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCSV(t *testing.T, contents string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "airports.csv")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestReadLookupIndexesByBothCodes(t *testing.T) {
+	path := writeCSV(t, "iata_code,icao_code,name,municipality,iso_country\n"+
+		"LAX,KLAX,Los Angeles Intl,Los Angeles,US\n")
+
+	lookup, err := ReadLookup(path)
+	if err != nil {
+		t.Fatalf("ReadLookup: %v", err)
+	}
+
+	a, ok := lookup.Resolve("LAX", "#")
+	if !ok || a.Name != "Los Angeles Intl" {
+		t.Errorf("Resolve(LAX, #) = %+v, %v", a, ok)
+	}
+	a, ok = lookup.Resolve("KLAX", "##")
+	if !ok || a.Name != "Los Angeles Intl" {
+		t.Errorf("Resolve(KLAX, ##) = %+v, %v", a, ok)
+	}
+	if _, ok := lookup.Resolve("LAX", "##"); ok {
+		t.Error("Resolve(LAX, ##) should miss: LAX is only registered as an IATA code")
+	}
+}
+
+func TestReadLookupRejectsMissingColumns(t *testing.T) {
+	path := writeCSV(t, "iata_code,name\nLAX,Los Angeles Intl\n")
+	if _, err := ReadLookup(path); err == nil {
+		t.Error("expected an error for a feed missing icao_code")
+	}
+}
+
+func TestReadLookupRejectsIncompleteRow(t *testing.T) {
+	path := writeCSV(t, "iata_code,icao_code,name,municipality,iso_country\n"+
+		"LAX,,Los Angeles Intl,Los Angeles,US\n")
+	if _, err := ReadLookup(path); err == nil {
+		t.Error("expected an error for a row with a blank icao_code")
+	}
+}
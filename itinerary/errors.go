@@ -0,0 +1,18 @@
+package itinerary
+
+import "fmt"
+
+// ParseError reports a malformed airport code or timestamp found while
+// lexing or parsing an itinerary. Line and Col are 1-based and point at
+// the start of the offending token so callers can render a diagnostic
+// such as "line 3, col 12: malformed IATA: "#1X"".
+type ParseError struct {
+	Line    int
+	Col     int
+	Kind    TokenKind
+	Snippet string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, col %d: malformed %s: %q", e.Line, e.Col, e.Kind, e.Snippet)
+}
@@ -0,0 +1,75 @@
+package itinerary
+
+// Leg is a single flight assembled from one or more consecutive
+// segments. Itineraries commonly split a leg's route and its times
+// across adjacent lines (e.g. the airport codes on one line, the
+// T24(...) times on the next), so Legs accumulates whichever of
+// Depart/Arrive/Date/DepTime/ArrTime a segment is missing from the
+// segments around it, rather than requiring all four on a single line.
+type Leg struct {
+	Line    int
+	Tokens  []Token
+	Depart  *Token
+	Arrive  *Token
+	Date    *Token
+	DepTime *Token
+	ArrTime *Token
+}
+
+func (l Leg) complete() bool {
+	return l.Depart != nil && l.Arrive != nil && l.DepTime != nil && l.ArrTime != nil
+}
+
+// Legs walks it.Segments in order, merging consecutive non-blank
+// segments into a Leg until Depart, Arrive, DepTime and ArrTime are all
+// present, then starts a new Leg. A blank segment (Segment.Empty) ends
+// whatever Leg was being assembled; if it wasn't complete, it's
+// discarded rather than returned half-filled.
+func Legs(it *Itinerary) []Leg {
+	var legs []Leg
+	var cur Leg
+	open := false
+
+	flush := func() {
+		if open && cur.complete() {
+			legs = append(legs, cur)
+		}
+		cur = Leg{}
+		open = false
+	}
+
+	for _, seg := range it.Segments {
+		if seg.Empty() {
+			flush()
+			continue
+		}
+
+		if !open {
+			cur.Line = seg.Line
+			open = true
+		}
+		cur.Tokens = append(cur.Tokens, seg.Tokens...)
+		if seg.Depart != nil && cur.Depart == nil {
+			cur.Depart = seg.Depart
+		}
+		if seg.Arrive != nil && cur.Arrive == nil {
+			cur.Arrive = seg.Arrive
+		}
+		if seg.Date != nil && cur.Date == nil {
+			cur.Date = seg.Date
+		}
+		if seg.DepTime != nil && cur.DepTime == nil {
+			cur.DepTime = seg.DepTime
+		}
+		if seg.ArrTime != nil && cur.ArrTime == nil {
+			cur.ArrTime = seg.ArrTime
+		}
+
+		if cur.complete() {
+			flush()
+		}
+	}
+	flush()
+
+	return legs
+}
@@ -0,0 +1,209 @@
+package itinerary
+
+import "strings"
+
+// maxMarkerSpan bounds how far the lexer scans for the closing ')' of a
+// D(...)/T12(...)/T24(...) marker, so a stray unterminated '(' in
+// untrusted input can't force an unbounded scan of the rest of the line.
+const maxMarkerSpan = 64
+
+// Lex splits input into per-line token slices, tracking the line and
+// column of each token so callers can render precise diagnostics. One
+// entry is produced per physical line, even if that line carries no
+// tokens, so blank lines survive round-tripping.
+func Lex(input string) ([][]Token, error) {
+	lines := strings.Split(input, "\n")
+	result := make([][]Token, len(lines))
+
+	for li, line := range lines {
+		toks, err := lexLine(line, li+1)
+		if err != nil {
+			return nil, err
+		}
+		result[li] = toks
+	}
+	return result, nil
+}
+
+func lexLine(line string, lineNo int) ([]Token, error) {
+	// Preallocate off the line's own length, not any count embedded in
+	// the input, and cap it so a single huge line can't force a huge
+	// up-front allocation before we've validated anything in it.
+	prealloc := len(line)/2 + 1
+	if prealloc > 128 {
+		prealloc = 128
+	}
+	tokens := make([]Token, 0, prealloc)
+
+	var text strings.Builder
+	textCol := 1
+	flush := func() {
+		if text.Len() > 0 {
+			tokens = append(tokens, Token{Kind: Text, Value: text.String(), Line: lineNo, Col: textCol})
+			text.Reset()
+		}
+	}
+	appendText := func(pos int) {
+		if text.Len() == 0 {
+			textCol = pos + 1
+		}
+		text.WriteByte(line[pos])
+	}
+
+	pos := 0
+	for pos < len(line) {
+		rest := line[pos:]
+		switch {
+		case strings.HasPrefix(rest, "##"):
+			tok, width, ok, err := readCode(line, pos+2, 4, lineNo, pos+1, ICAO)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				appendText(pos)
+				pos++
+				continue
+			}
+			flush()
+			tokens = append(tokens, tok)
+			pos += 2 + width
+
+		case strings.HasPrefix(rest, "#"):
+			tok, width, ok, err := readCode(line, pos+1, 3, lineNo, pos+1, IATA)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				appendText(pos)
+				pos++
+				continue
+			}
+			flush()
+			tokens = append(tokens, tok)
+			pos += 1 + width
+
+		case strings.HasPrefix(rest, "T24("):
+			tok, width, ok, err := readMarker(line, pos, "T24(", lineNo, Time24)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				appendText(pos)
+				pos++
+				continue
+			}
+			zone, zoneWidth := readZoneSuffix(line, pos+width)
+			tok.Zone = zone
+			flush()
+			tokens = append(tokens, tok)
+			pos += width + zoneWidth
+
+		case strings.HasPrefix(rest, "T12("):
+			tok, width, ok, err := readMarker(line, pos, "T12(", lineNo, Time12)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				appendText(pos)
+				pos++
+				continue
+			}
+			zone, zoneWidth := readZoneSuffix(line, pos+width)
+			tok.Zone = zone
+			flush()
+			tokens = append(tokens, tok)
+			pos += width + zoneWidth
+
+		case strings.HasPrefix(rest, "D("):
+			tok, width, ok, err := readMarker(line, pos, "D(", lineNo, DateOnly)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				appendText(pos)
+				pos++
+				continue
+			}
+			zone, zoneWidth := readZoneSuffix(line, pos+width)
+			tok.Zone = zone
+			flush()
+			tokens = append(tokens, tok)
+			pos += width + zoneWidth
+
+		default:
+			appendText(pos)
+			pos++
+		}
+	}
+	flush()
+	return tokens, nil
+}
+
+// readCode reads an n-byte airport code starting at start. ok is false
+// (with a nil error) both when fewer than n bytes remain and when a
+// full-length candidate is present but contains a rune outside
+// [A-Z0-9]: either way there's no valid code here, and the '#'/'##'
+// that led the caller to try is just literal text, matching the
+// original tool's pass-through behavior for unrecognized markers.
+func readCode(line string, start, n, lineNo, col int, kind TokenKind) (Token, int, bool, error) {
+	if start+n > len(line) {
+		return Token{}, 0, false, nil
+	}
+	code := line[start : start+n]
+	for _, r := range code {
+		if !isCodeRune(r) {
+			return Token{}, 0, false, nil
+		}
+	}
+	return Token{Kind: kind, Value: code, Line: lineNo, Col: col}, n, true, nil
+}
+
+func isCodeRune(r rune) bool {
+	return (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// readMarker reads a prefix(...) marker such as T24(2024-03-01T09:00Z),
+// validating that its contents parse as one of the accepted timestamp
+// layouts. width is the number of bytes consumed, including the prefix
+// and both parentheses. ok is false (with a nil error) when the marker
+// is unterminated or its contents don't parse as a timestamp: either
+// way it's not a real marker, and the caller falls back to treating the
+// leading prefix byte as literal text, matching readCode's pass-through
+// behavior for unrecognized '#'/'##' codes.
+func readMarker(line string, pos int, prefix string, lineNo int, kind TokenKind) (Token, int, bool, error) {
+	body := line[pos+len(prefix):]
+	scan := body
+	if len(scan) > maxMarkerSpan {
+		scan = scan[:maxMarkerSpan]
+	}
+	end := strings.IndexByte(scan, ')')
+	if end == -1 {
+		return Token{}, 0, false, nil
+	}
+
+	value := body[:end]
+	if _, err := parseMarkerTime(value); err != nil {
+		return Token{}, 0, false, nil
+	}
+
+	width := len(prefix) + end + 1
+	return Token{Kind: kind, Value: value, Line: lineNo, Col: pos + 1}, width, true, nil
+}
+
+// readZoneSuffix reads an optional "@Area/Location" IANA zone name
+// immediately following a date/time marker's closing ')'. It returns an
+// empty zone and zero width if no '@' is present at pos.
+func readZoneSuffix(line string, pos int) (string, int) {
+	if pos >= len(line) || line[pos] != '@' {
+		return "", 0
+	}
+	end := pos + 1
+	for end < len(line) && isZoneRune(rune(line[end])) {
+		end++
+	}
+	return line[pos+1 : end], end - pos
+}
+
+func isZoneRune(r rune) bool {
+	return (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '/' || r == '_' || r == '+' || r == '-'
+}
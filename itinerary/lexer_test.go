@@ -0,0 +1,81 @@
+package itinerary
+
+import "testing"
+
+func TestLexLineRecognizesCodesAndMarkers(t *testing.T) {
+	toks, err := lexLine("#LAX ##KJFK D(2024-03-01T09:00Z)", 1)
+	if err != nil {
+		t.Fatalf("lexLine: %v", err)
+	}
+	wantKinds := []TokenKind{IATA, Text, ICAO, Text, DateOnly}
+	if len(toks) != len(wantKinds) {
+		t.Fatalf("got %d tokens, want %d: %+v", len(toks), len(wantKinds), toks)
+	}
+	for i, want := range wantKinds {
+		if toks[i].Kind != want {
+			t.Errorf("token %d: got kind %s, want %s", i, toks[i].Kind, want)
+		}
+	}
+	if toks[0].Value != "LAX" {
+		t.Errorf("IATA value = %q, want %q", toks[0].Value, "LAX")
+	}
+	if toks[2].Value != "KJFK" {
+		t.Errorf("ICAO value = %q, want %q", toks[2].Value, "KJFK")
+	}
+	if toks[4].Value != "2024-03-01T09:00Z" {
+		t.Errorf("DateOnly value = %q, want %q", toks[4].Value, "2024-03-01T09:00Z")
+	}
+}
+
+func TestLexLinePassesThroughUnrecognizedCodes(t *testing.T) {
+	// "#12a" isn't a valid IATA code (lowercase 'a'), so it should read as
+	// plain text rather than raising an error, matching the baseline
+	// tool's tolerance of stray '#' characters in free text.
+	toks, err := lexLine("Gate #12a is closed", 1)
+	if err != nil {
+		t.Fatalf("lexLine returned an error for a malformed code: %v", err)
+	}
+	if len(toks) != 1 || toks[0].Kind != Text {
+		t.Fatalf("got %+v, want a single Text token", toks)
+	}
+	if toks[0].Value != "Gate #12a is closed" {
+		t.Errorf("Value = %q, want the input unchanged", toks[0].Value)
+	}
+}
+
+func TestLexLinePassesThroughUnparseableMarkers(t *testing.T) {
+	cases := []struct {
+		name string
+		line string
+	}{
+		{"unterminated", "Flight leaves T24(08:00 and arrives later"},
+		{"bad timestamp", "Meeting at T24(not-a-time) today"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			toks, err := lexLine(tc.line, 1)
+			if err != nil {
+				t.Fatalf("lexLine returned an error for an unparseable marker: %v", err)
+			}
+			if len(toks) != 1 || toks[0].Kind != Text {
+				t.Fatalf("got %+v, want a single Text token", toks)
+			}
+			if toks[0].Value != tc.line {
+				t.Errorf("Value = %q, want the input unchanged", toks[0].Value)
+			}
+		})
+	}
+}
+
+func TestLexLineReadsAWellFormedMarker(t *testing.T) {
+	toks, err := lexLine("T24(2024-03-01T09:00Z)@Europe/Paris", 1)
+	if err != nil {
+		t.Fatalf("lexLine: %v", err)
+	}
+	if len(toks) != 1 || toks[0].Kind != Time24 {
+		t.Fatalf("got %+v, want a single Time24 token", toks)
+	}
+	if toks[0].Zone != "Europe/Paris" {
+		t.Errorf("Zone = %q, want %q", toks[0].Zone, "Europe/Paris")
+	}
+}
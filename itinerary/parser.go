@@ -0,0 +1,92 @@
+package itinerary
+
+import "strings"
+
+// Segment is one parsed line of an itinerary. Depart/Arrive point at the
+// first two airport-code tokens on the line and DepTime/ArrTime at the
+// first two Time12/Time24 tokens (a leg's actual clock times), which is
+// enough structure for continuity checks without re-scanning Tokens. Date
+// is the line's first DateOnly token, if any, kept separate since it
+// stamps the segment as a whole rather than either leg of it.
+type Segment struct {
+	Line    int
+	Tokens  []Token
+	Depart  *Token
+	Arrive  *Token
+	Date    *Token
+	DepTime *Token
+	ArrTime *Token
+}
+
+// Itinerary is the parsed form of an input document: one Segment per
+// physical line.
+type Itinerary struct {
+	Segments []Segment
+}
+
+// Parse lexes input and groups its tokens into per-line segments.
+func Parse(input string) (*Itinerary, error) {
+	perLine, err := Lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]Segment, len(perLine))
+	for i, toks := range perLine {
+		segments[i] = newSegment(i+1, toks)
+	}
+	return &Itinerary{Segments: segments}, nil
+}
+
+// ParseLine parses a single line in isolation, as though it were line
+// lineNo of a larger document. It exists for callers that process a
+// document line by line (e.g. the streaming conversion pipeline) but
+// still need ParseError and Segment.Line to report the line's true
+// position rather than always reporting line 1.
+func ParseLine(line string, lineNo int) (*Itinerary, error) {
+	toks, err := lexLine(line, lineNo)
+	if err != nil {
+		return nil, err
+	}
+	return &Itinerary{Segments: []Segment{newSegment(lineNo, toks)}}, nil
+}
+
+// Empty reports whether a segment carries no real content: either it has
+// no tokens at all, or every token is Text made up entirely of
+// whitespace. Blank lines parse this way, and renderers that work
+// segment-by-segment use this to skip them rather than emitting an empty
+// bullet, table or similar.
+func (seg *Segment) Empty() bool {
+	for _, tok := range seg.Tokens {
+		if tok.Kind != Text || strings.TrimSpace(tok.Value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func newSegment(lineNo int, tokens []Token) Segment {
+	seg := Segment{Line: lineNo, Tokens: tokens}
+	for i := range tokens {
+		tok := &tokens[i]
+		switch tok.Kind {
+		case IATA, ICAO:
+			if seg.Depart == nil {
+				seg.Depart = tok
+			} else if seg.Arrive == nil {
+				seg.Arrive = tok
+			}
+		case DateOnly:
+			if seg.Date == nil {
+				seg.Date = tok
+			}
+		case Time12, Time24:
+			if seg.DepTime == nil {
+				seg.DepTime = tok
+			} else if seg.ArrTime == nil {
+				seg.ArrTime = tok
+			}
+		}
+	}
+	return seg
+}
@@ -0,0 +1,175 @@
+package itinerary
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+)
+
+// Resolved pairs a token with the text it renders as: an airport code
+// resolved to its name (or --template expansion), or a date/time marker
+// formatted for display. Free-form Text tokens resolve to their own
+// Value unchanged.
+type Resolved struct {
+	Kind TokenKind
+	Text string
+}
+
+// Resolve renders every segment's tokens to display text without joining
+// them into a single string, so renderers other than plain text (e.g.
+// Markdown, HTML) can apply their own formatting around each token.
+//
+// tmpl controls how a resolved airport is rendered; pass nil to use just
+// its name, matching the tool's original output. tz is the target
+// timezone every instant is converted into before formatting; pass nil
+// to leave instants in whatever offset they were written with. A
+// token's own @Zone suffix, if present, overrides tz for that token
+// alone.
+func (it *Itinerary) Resolve(lookup airport.Lookup, tmpl *template.Template, tz *time.Location) ([][]Resolved, error) {
+	zones := newZoneCache(tz)
+	out := make([][]Resolved, len(it.Segments))
+	for i := range it.Segments {
+		resolved, err := it.Segments[i].resolve(lookup, tmpl, zones)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = resolved
+	}
+	return out, nil
+}
+
+func (seg *Segment) resolve(lookup airport.Lookup, tmpl *template.Template, zones *zoneCache) ([]Resolved, error) {
+	resolved := make([]Resolved, len(seg.Tokens))
+	for i, tok := range seg.Tokens {
+		switch tok.Kind {
+		case Text:
+			resolved[i] = Resolved{Kind: Text, Text: tok.Value}
+		case IATA:
+			text, err := ResolveCode(lookup, tok.Value, "#", tmpl)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = Resolved{Kind: IATA, Text: text}
+		case ICAO:
+			text, err := ResolveCode(lookup, tok.Value, "##", tmpl)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = Resolved{Kind: ICAO, Text: text}
+		case DateOnly:
+			t, err := zones.resolve(tok)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = Resolved{Kind: DateOnly, Text: t.Format("02 Jan 2006")}
+		case Time12:
+			t, err := zones.resolve(tok)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = Resolved{Kind: Time12, Text: t.Format("03:04PM (-07:00)")}
+		case Time24:
+			t, err := zones.resolve(tok)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = Resolved{Kind: Time24, Text: t.Format("15:04 (-07:00)")}
+		}
+	}
+	return resolved, nil
+}
+
+// RenderPlain reproduces the itinerary as human-readable text: airport
+// codes are resolved against lookup (via tmpl, or just by name if tmpl
+// is nil) and date/time markers are formatted for a traveler to read.
+// Codes with no match in lookup are left as the original #CODE/##CODE
+// literal.
+func (it *Itinerary) RenderPlain(lookup airport.Lookup, tmpl *template.Template, tz *time.Location) (string, error) {
+	resolved, err := it.Resolve(lookup, tmpl, tz)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, len(resolved))
+	for i, tokens := range resolved {
+		var b strings.Builder
+		for _, tok := range tokens {
+			b.WriteString(tok.Text)
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// ResolveCode looks up code (an IATA or ICAO value, without its marker)
+// in lookup and renders it with tmpl (or just its name if tmpl is nil),
+// or returns the original marker+code literal if code isn't found.
+func ResolveCode(lookup airport.Lookup, code, marker string, tmpl *template.Template) (string, error) {
+	a, ok := lookup.Resolve(code, marker)
+	if !ok {
+		return marker + code, nil
+	}
+	return a.Render(tmpl)
+}
+
+// ResolveTime parses a date/time token's marker value and converts it
+// into tz, unless the token carries its own @Zone override. Unlike the
+// zoneCache used internally by Resolve, it does not cache time.LoadLocation
+// lookups, so it's meant for one-off use such as building calendar events.
+func ResolveTime(tok Token, tz *time.Location) (time.Time, error) {
+	t, err := parseMarkerTime(tok.Value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if tok.Zone != "" {
+		loc, err := time.LoadLocation(tok.Zone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown zone %q: %w", tok.Zone, err)
+		}
+		return t.In(loc), nil
+	}
+	if tz != nil {
+		return t.In(tz), nil
+	}
+	return t, nil
+}
+
+// zoneCache resolves a token's effective timezone, caching time.LoadLocation
+// lookups for @Zone suffixes since the same zone typically repeats across
+// many segments of an itinerary.
+type zoneCache struct {
+	def      *time.Location
+	resolved map[string]*time.Location
+}
+
+func newZoneCache(def *time.Location) *zoneCache {
+	return &zoneCache{def: def, resolved: make(map[string]*time.Location)}
+}
+
+func (z *zoneCache) resolve(tok Token) (time.Time, error) {
+	t, err := parseMarkerTime(tok.Value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if tok.Zone == "" {
+		if z.def == nil {
+			return t, nil
+		}
+		return t.In(z.def), nil
+	}
+
+	loc, ok := z.resolved[tok.Zone]
+	if !ok {
+		var err error
+		loc, err = time.LoadLocation(tok.Zone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown zone %q: %w", tok.Zone, err)
+		}
+		z.resolved[tok.Zone] = loc
+	}
+	return t.In(loc), nil
+}
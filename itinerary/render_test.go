@@ -0,0 +1,83 @@
+package itinerary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+)
+
+func TestResolveTimeZoneOverridePrecedence(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	tok := Token{Kind: Time24, Value: "2024-03-01T09:00Z", Zone: "Asia/Tokyo"}
+
+	got, err := ResolveTime(tok, paris)
+	if err != nil {
+		t.Fatalf("ResolveTime: %v", err)
+	}
+	want := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC).In(tokyo)
+	if !got.Equal(want) || got.Location().String() != tokyo.String() {
+		t.Errorf("got %v in %s, want %v in %s: the token's own @Zone should win over --tz",
+			got, got.Location(), want, tokyo)
+	}
+}
+
+func TestResolveTimeFallsBackToTZWithoutZoneSuffix(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	tok := Token{Kind: Time24, Value: "2024-03-01T09:00Z"}
+	got, err := ResolveTime(tok, paris)
+	if err != nil {
+		t.Fatalf("ResolveTime: %v", err)
+	}
+	if got.Location().String() != paris.String() {
+		t.Errorf("got zone %s, want %s", got.Location(), paris)
+	}
+}
+
+func TestResolveTimeLeavesInstantUnchangedWithoutTZ(t *testing.T) {
+	tok := Token{Kind: Time24, Value: "2024-03-01T09:00Z"}
+	got, err := ResolveTime(tok, nil)
+	if err != nil {
+		t.Fatalf("ResolveTime: %v", err)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("got zone %s, want UTC unchanged", got.Location())
+	}
+}
+
+func TestResolveAppliesPerTokenZoneOverride(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	it := &Itinerary{Segments: []Segment{
+		newSegment(1, []Token{
+			{Kind: Time24, Value: "2024-03-01T09:00Z", Zone: "Asia/Tokyo"},
+			{Kind: Time24, Value: "2024-03-01T09:00Z"},
+		}),
+	}}
+
+	resolved, err := it.Resolve(airport.Lookup{}, nil, paris)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	overridden := resolved[0][0].Text
+	plain := resolved[0][1].Text
+	if overridden == plain {
+		t.Errorf("token with @Zone override rendered the same as the --tz default: both %q", overridden)
+	}
+}
@@ -0,0 +1,28 @@
+package itinerary
+
+import (
+	"fmt"
+	"time"
+)
+
+// isoLayouts are the timestamp layouts accepted inside D(...), T12(...)
+// and T24(...) markers: a bare UTC instant, or one with an explicit
+// numeric offset.
+var isoLayouts = []string{
+	"2006-01-02T15:04Z",
+	"2006-01-02T15:04-07:00",
+}
+
+// parseMarkerTime parses the raw contents of a D/T12/T24 marker against
+// every accepted layout, returning the first match.
+func parseMarkerTime(value string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range isoLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, fmt.Errorf("parse timestamp %q: %w", value, lastErr)
+}
@@ -0,0 +1,53 @@
+package itinerary
+
+// TokenKind identifies the lexical category of a Token.
+type TokenKind int
+
+const (
+	// Text is a run of free-form characters with no special meaning.
+	Text TokenKind = iota
+	// IATA is a three-letter airport code written as #XXX.
+	IATA
+	// ICAO is a four-letter airport code written as ##XXXX.
+	ICAO
+	// DateOnly is a bare calendar date written as D(2006-01-02).
+	DateOnly
+	// Time12 is a 12-hour timestamp written as T12(2006-01-02T15:04Z07:00).
+	Time12
+	// Time24 is a 24-hour timestamp written as T24(2006-01-02T15:04Z07:00).
+	Time24
+)
+
+// String renders the kind as it should appear in diagnostics.
+func (k TokenKind) String() string {
+	switch k {
+	case Text:
+		return "Text"
+	case IATA:
+		return "IATA"
+	case ICAO:
+		return "ICAO"
+	case DateOnly:
+		return "DateOnly"
+	case Time12:
+		return "Time12"
+	case Time24:
+		return "Time24"
+	default:
+		return "Unknown"
+	}
+}
+
+// Token is a single lexed unit of an itinerary line. Value holds the raw
+// payload: the bare code for IATA/ICAO tokens, the timestamp literal for
+// DateOnly/Time12/Time24 tokens, or the literal text for Text tokens.
+// Zone is set on a DateOnly/Time12/Time24 token when it carries an
+// inline @Zone suffix (e.g. T24(2024-03-01T09:00Z)@Europe/Paris), pinning
+// its display timezone regardless of any --tz flag.
+type Token struct {
+	Kind  TokenKind
+	Value string
+	Zone  string
+	Line  int
+	Col   int
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+// scannerBufferSize bounds how long a single itinerary line can be before
+// bufio.Scanner gives up, so the streaming pipeline never has to load more
+// than one line's worth of a pathological input into memory at once.
+const scannerBufferSize = 1 << 20
+
+// logicalLine is one line to render: text is the line itself, lineNo is
+// its real position in the input document (for diagnostics), and index
+// is its position in the output, which can differ from lineNo once
+// blank-line collapsing or \v/\f/\r expansion has run.
+type logicalLine struct {
+	index  int
+	lineNo int
+	text   string
+}
+
+// streamConvert reads itinerary lines from r, converts each one (airport
+// codes resolved, date/time markers formatted) and writes the result to
+// w line by line. It never holds the whole document in memory: scanLines
+// produces one line at a time, and at most a handful of rendered lines
+// are ever buffered at once — one per worker, plus whatever the jobs>1
+// reordering window needs to put output back in order.
+//
+// jobs controls how many lines are converted concurrently; jobs <= 1
+// processes strictly in order on the calling goroutine. With jobs > 1,
+// conversions happen on a worker pool and results are reassembled into
+// their original order before being written, so output is deterministic
+// regardless of how the workers finish.
+func streamConvert(r io.Reader, w io.Writer, lookup airport.Lookup, tmpl *template.Template, tz *time.Location, jobs int) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if jobs == 1 {
+		index := 0
+		for ll := range scanLines(r) {
+			rendered, err := renderLine(ll.text, ll.lineNo, lookup, tmpl, tz)
+			if err != nil {
+				return err
+			}
+			if err := writeLine(bw, index, rendered); err != nil {
+				return err
+			}
+			index++
+		}
+		return bw.Flush()
+	}
+
+	type result struct {
+		index int
+		line  string
+		err   error
+	}
+
+	jobCh := make(chan logicalLine, jobs)
+	resultCh := make(chan result, jobs)
+
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ll := range jobCh {
+				rendered, err := renderLine(ll.text, ll.lineNo, lookup, tmpl, tz)
+				resultCh <- result{index: ll.index, line: rendered, err: err}
+			}
+		}()
+	}
+	go func() {
+		for ll := range scanLines(r) {
+			jobCh <- ll
+		}
+		close(jobCh)
+	}()
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	// Workers finish out of order; buffer completed lines until the next
+	// one we need to write is ready, then flush in sequence. Since the
+	// producer only keeps jobs lines in flight at once, this window
+	// never grows past roughly jobs entries.
+	pending := make(map[int]string, jobs)
+	next := 0
+	var firstErr error
+	for res := range resultCh {
+		if res.err != nil && firstErr == nil {
+			firstErr = res.err
+		}
+		pending[res.index] = res.line
+		for {
+			line, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			if firstErr == nil {
+				if err := writeLine(bw, next, line); err != nil {
+					firstErr = err
+				}
+			}
+			next++
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return bw.Flush()
+}
+
+func writeLine(bw *bufio.Writer, index int, line string) error {
+	if index > 0 {
+		if _, err := bw.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	_, err := bw.WriteString(line)
+	return err
+}
+
+// renderLine converts a single itinerary line in isolation, parsing it as
+// though it were line lineNo of a larger document so any ParseError (or
+// Segment.Line, for callers that inspect it) reports the line's true
+// position instead of always reporting line 1.
+func renderLine(line string, lineNo int, lookup airport.Lookup, tmpl *template.Template, tz *time.Location) (string, error) {
+	it, err := itinerary.ParseLine(line, lineNo)
+	if err != nil {
+		return "", err
+	}
+	return it.RenderPlain(lookup, tmpl, tz)
+}
+
+// scanLines reads r one physical line at a time and streams out logical
+// lines, normalizing \r, \v and \f to \n and collapsing runs of blank
+// lines down to one, mirroring the whitespace handling processInputData
+// used to apply to the whole document in one pass. Only the current
+// line is ever held in memory; nothing is buffered ahead of the reader.
+func scanLines(r io.Reader) <-chan logicalLine {
+	out := make(chan logicalLine)
+	go func() {
+		defer close(out)
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), scannerBufferSize)
+
+		index := 0
+		lineNo := 0
+		blankRun := 0
+		for scanner.Scan() {
+			lineNo++
+			raw := scanner.Text()
+			raw = strings.ReplaceAll(raw, "\v", "\n")
+			raw = strings.ReplaceAll(raw, "\f", "\n")
+			raw = strings.ReplaceAll(raw, "\r", "\n")
+
+			for _, sub := range strings.Split(raw, "\n") {
+				if strings.TrimSpace(sub) == "" {
+					blankRun++
+					if blankRun > 1 {
+						continue
+					}
+				} else {
+					blankRun = 0
+				}
+				out <- logicalLine{index: index, lineNo: lineNo, text: sub}
+				index++
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+func TestStreamConvertOrdersOutputRegardlessOfJobs(t *testing.T) {
+	input := "#LAX line one\n#JFK line two\n#ORD line three\n#DFW line four\n#ATL line five\n"
+	lookup := airport.Lookup{}
+
+	var single strings.Builder
+	if err := streamConvert(strings.NewReader(input), &single, lookup, nil, nil, 1); err != nil {
+		t.Fatalf("streamConvert jobs=1: %v", err)
+	}
+
+	for _, jobs := range []int{2, 4, 8} {
+		var out strings.Builder
+		if err := streamConvert(strings.NewReader(input), &out, lookup, nil, nil, jobs); err != nil {
+			t.Fatalf("streamConvert jobs=%d: %v", jobs, err)
+		}
+		if out.String() != single.String() {
+			t.Errorf("jobs=%d output doesn't match jobs=1 output:\n jobs=%d: %q\n jobs=1: %q",
+				jobs, jobs, out.String(), single.String())
+		}
+	}
+}
+
+func TestRenderLineStampsItsTrueLineNumber(t *testing.T) {
+	// renderLine parses each line in isolation via itinerary.ParseLine, so
+	// it must be told lineNo explicitly rather than always reporting 1.
+	it, err := itinerary.ParseLine("#LAX", 7)
+	if err != nil {
+		t.Fatalf("ParseLine: %v", err)
+	}
+	if len(it.Segments) != 1 || it.Segments[0].Line != 7 {
+		t.Fatalf("got segment %+v, want Line 7", it.Segments)
+	}
+}
+
+func TestScanLinesCollapsesBlankRuns(t *testing.T) {
+	input := "a\n\n\n\nb\n"
+	var lines []string
+	for ll := range scanLines(strings.NewReader(input)) {
+		lines = append(lines, ll.text)
+	}
+	want := []string{"a", "", "b"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines %q, want %d lines %q", len(lines), lines, len(want), want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
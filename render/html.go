@@ -0,0 +1,42 @@
+package render
+
+import (
+	"fmt"
+	htmlpkg "html"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+// HTML renders each segment as its own table, one row per token, so a
+// line's airport codes, dates and times read as structured fields
+// instead of running text.
+type HTML struct{}
+
+func (HTML) Render(it *itinerary.Itinerary, lookup airport.Lookup, tmpl *template.Template, tz *time.Location) (string, error) {
+	resolved, err := it.Resolve(lookup, tmpl, tz)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	first := true
+	for i, tokens := range resolved {
+		if it.Segments[i].Empty() {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+		b.WriteString("<table>\n")
+		for _, tok := range tokens {
+			fmt.Fprintf(&b, "  <tr><td>%s</td><td>%s</td></tr>\n", tok.Kind, htmlpkg.EscapeString(tok.Text))
+		}
+		b.WriteString("</table>")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,88 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+// ICS renders each leg (a departure/arrival airport and time pair,
+// possibly assembled from several consecutive lines - see
+// itinerary.Legs) as an RFC 5545 VEVENT, so a traveler can import the
+// itinerary straight into a calendar app.
+type ICS struct{}
+
+func (ICS) Render(it *itinerary.Itinerary, lookup airport.Lookup, tmpl *template.Template, tz *time.Location) (string, error) {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//Itinerary Prettifier//EN\r\n")
+
+	dtstamp := time.Now().UTC().Format("20060102T150405Z")
+
+	for legIndex, leg := range itinerary.Legs(it) {
+		dep, err := itinerary.ResolveTime(*leg.DepTime, tz)
+		if err != nil {
+			return "", err
+		}
+		arr, err := itinerary.ResolveTime(*leg.ArrTime, tz)
+		if err != nil {
+			return "", err
+		}
+
+		depName, err := resolveAirport(lookup, leg.Depart, tmpl)
+		if err != nil {
+			return "", err
+		}
+		arrName, err := resolveAirport(lookup, leg.Arrive, tmpl)
+		if err != nil {
+			return "", err
+		}
+
+		depStart := dep.UTC().Format("20060102T150405Z")
+
+		b.WriteString("BEGIN:VEVENT\r\n")
+		fmt.Fprintf(&b, "UID:%d-%s-%s-%s@itinerary-prettifier\r\n", legIndex, leg.Depart.Value, leg.Arrive.Value, depStart)
+		fmt.Fprintf(&b, "DTSTAMP:%s\r\n", dtstamp)
+		fmt.Fprintf(&b, "DTSTART:%s\r\n", depStart)
+		fmt.Fprintf(&b, "DTEND:%s\r\n", arr.UTC().Format("20060102T150405Z"))
+		fmt.Fprintf(&b, "SUMMARY:%s to %s\r\n", icsEscape(depName), icsEscape(arrName))
+		fmt.Fprintf(&b, "LOCATION:%s\r\n", icsEscape(depName))
+		b.WriteString("END:VEVENT\r\n")
+	}
+
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String(), nil
+}
+
+func resolveAirport(lookup airport.Lookup, tok *itinerary.Token, tmpl *template.Template) (string, error) {
+	marker := "#"
+	if tok.Kind == itinerary.ICAO {
+		marker = "##"
+	}
+	return itinerary.ResolveCode(lookup, tok.Value, marker, tmpl)
+}
+
+// icsReplacer escapes the RFC 5545 TEXT special characters, backslash
+// first so it doesn't double-escape the backslashes this same call
+// introduces for the others. A bare \r has no TEXT escape sequence of
+// its own and would otherwise read as a stray CRLF line terminator to
+// a strict parser, so it's dropped rather than escaped.
+var icsReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\n", `\n`,
+	"\r", "",
+)
+
+// icsEscape escapes a value (e.g. a --template-rendered airport name)
+// for use in an RFC 5545 TEXT property, so a comma or semicolon in it
+// can't be mistaken for a property delimiter by a strict ical parser.
+func icsEscape(s string) string {
+	return icsReplacer.Replace(s)
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+// Markdown renders each segment as a bulleted list item, with resolved
+// airport names bolded and formatted dates/times italicized.
+type Markdown struct{}
+
+func (Markdown) Render(it *itinerary.Itinerary, lookup airport.Lookup, tmpl *template.Template, tz *time.Location) (string, error) {
+	resolved, err := it.Resolve(lookup, tmpl, tz)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for i, tokens := range resolved {
+		if it.Segments[i].Empty() {
+			continue
+		}
+		var b strings.Builder
+		b.WriteString("- ")
+		for _, tok := range tokens {
+			switch tok.Kind {
+			case itinerary.IATA, itinerary.ICAO:
+				b.WriteString("**")
+				b.WriteString(tok.Text)
+				b.WriteString("**")
+			case itinerary.DateOnly, itinerary.Time12, itinerary.Time24:
+				b.WriteString("*")
+				b.WriteString(tok.Text)
+				b.WriteString("*")
+			default:
+				b.WriteString(tok.Text)
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,17 @@
+package render
+
+import (
+	"text/template"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+// Plain renders an itinerary exactly as the tool always has: airport
+// codes resolved to names and date/time markers formatted, nothing else.
+type Plain struct{}
+
+func (Plain) Render(it *itinerary.Itinerary, lookup airport.Lookup, tmpl *template.Template, tz *time.Location) (string, error) {
+	return it.RenderPlain(lookup, tmpl, tz)
+}
@@ -0,0 +1,38 @@
+// Package render formats a parsed itinerary.Itinerary for a particular
+// output medium: plain text, Markdown, HTML or iCalendar.
+package render
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+// Renderer formats an Itinerary into a single output string. tmpl, if
+// non-nil, controls how a resolved airport is rendered (see
+// airport.ParseTemplate); pass nil to render just its name. tz, if
+// non-nil, is the timezone every instant is converted into before
+// formatting, unless a segment pins its own @Zone.
+type Renderer interface {
+	Render(it *itinerary.Itinerary, lookup airport.Lookup, tmpl *template.Template, tz *time.Location) (string, error)
+}
+
+// ByName returns the Renderer registered for a --format value. "" is
+// treated the same as "plain".
+func ByName(name string) (Renderer, error) {
+	switch name {
+	case "", "plain":
+		return Plain{}, nil
+	case "markdown":
+		return Markdown{}, nil
+	case "html":
+		return HTML{}, nil
+	case "ics":
+		return ICS{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", name)
+	}
+}
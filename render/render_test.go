@@ -0,0 +1,108 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+func parse(t *testing.T, input string) *itinerary.Itinerary {
+	t.Helper()
+	it, err := itinerary.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return it
+}
+
+func TestByNameResolvesEachFormat(t *testing.T) {
+	cases := map[string]Renderer{
+		"":         Plain{},
+		"plain":    Plain{},
+		"markdown": Markdown{},
+		"html":     HTML{},
+		"ics":      ICS{},
+	}
+	for name, want := range cases {
+		got, err := ByName(name)
+		if err != nil {
+			t.Fatalf("ByName(%q): %v", name, err)
+		}
+		if got != want {
+			t.Errorf("ByName(%q) = %T, want %T", name, got, want)
+		}
+	}
+	if _, err := ByName("yaml"); err == nil {
+		t.Error("ByName(\"yaml\") should have errored on an unknown format")
+	}
+}
+
+func TestMarkdownSkipsBlankSegments(t *testing.T) {
+	it := parse(t, "#LAX\n\n#JFK\n")
+	out, err := Markdown{}.Render(it, airport.Lookup{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if strings.Contains(out, "- \n") || strings.HasSuffix(out, "- ") {
+		t.Errorf("blank segment rendered as a stray bullet: %q", out)
+	}
+	if got := strings.Count(out, "- "); got != 2 {
+		t.Errorf("got %d bullets, want 2: %q", got, out)
+	}
+}
+
+func TestHTMLSkipsBlankSegments(t *testing.T) {
+	it := parse(t, "#LAX\n\n#JFK\n")
+	out, err := HTML{}.Render(it, airport.Lookup{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if strings.Contains(out, "<table>\n</table>") {
+		t.Errorf("blank segment rendered as an empty table: %q", out)
+	}
+	if got := strings.Count(out, "<table>"); got != 2 {
+		t.Errorf("got %d tables, want 2: %q", got, out)
+	}
+}
+
+func TestICSAssemblesLegsSplitAcrossLines(t *testing.T) {
+	it := parse(t, "#LAX #JFK\nD(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T12:00Z)\n")
+	out, err := ICS{}.Render(it, airport.Lookup{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got := strings.Count(out, "BEGIN:VEVENT"); got != 1 {
+		t.Fatalf("got %d VEVENTs, want 1 assembled from the two lines: %q", got, out)
+	}
+	if !strings.Contains(out, "UID:") || !strings.Contains(out, "DTSTAMP:") {
+		t.Errorf("VEVENT is missing UID or DTSTAMP: %q", out)
+	}
+}
+
+func TestICSEscapesSummaryAndLocation(t *testing.T) {
+	it := parse(t, "#LAX #JFK D(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T12:00Z)\n")
+	lookup := airport.Lookup{ByIATA: map[string]airport.Airport{
+		"LAX": {Name: "LAX, Terminal; Gate", IATA: "LAX"},
+		"JFK": {Name: "JFK International", IATA: "JFK"},
+	}}
+	out, err := ICS{}.Render(it, lookup, nil, nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(out, `LAX\, Terminal\; Gate`) {
+		t.Errorf("comma/semicolon in airport name weren't escaped: %q", out)
+	}
+}
+
+func TestICSProducesNoEventsWithoutAnyCompleteLeg(t *testing.T) {
+	it := parse(t, "no airports or times here\n")
+	out, err := ICS{}.Render(it, airport.Lookup{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if strings.Contains(out, "BEGIN:VEVENT") {
+		t.Errorf("expected no VEVENTs, got %q", out)
+	}
+}
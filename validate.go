@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+// flightNumberPattern matches a typical IATA flight designator (an
+// airline code of 2-3 letters followed by a 1-4 digit flight number,
+// e.g. "AF007", "BA212") inside a segment's free-form Text tokens. The
+// itinerary format has no dedicated flight-number token, so this is how
+// validate finds one to check against a --schedule feed.
+var flightNumberPattern = regexp.MustCompile(`\b[A-Z]{2,3}[0-9]{1,4}\b`)
+
+const (
+	minConnectionDomestic      = 45 * time.Minute
+	minConnectionInternational = 90 * time.Minute
+)
+
+// violation is one continuity or schedule problem found between or
+// within segments, reported with the line it originates from.
+type violation struct {
+	line    int
+	message string
+}
+
+func (v violation) String() string {
+	return fmt.Sprintf("line %d: %s", v.line, v.message)
+}
+
+// scheduleEntry is one row of a GTFS-like flight feed used to cross-check
+// segments against real scheduled departure/arrival times.
+type scheduleEntry struct {
+	flight  string
+	depIATA string
+	arrIATA string
+	depTime string
+	arrTime string
+}
+
+// schedule indexes a feed's rows by flight number, since that's how
+// validate looks them up against a segment.
+type schedule map[string]scheduleEntry
+
+// runValidate implements the "validate" subcommand: parse an itinerary
+// and report continuity violations between consecutive segments, plus an
+// optional cross-check against a schedule feed.
+func runValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	tz := fs.String("tz", "", "interpret itinerary times in this IANA timezone before comparing them")
+	schedulePath := fs.String("schedule", "", "GTFS-like CSV feed (flight,dep_iata,arr_iata,dep_time,arr_time) to cross-check segments against")
+	fs.Usage = func() {
+		fmt.Println("itinerary validate usage:")
+		fmt.Println("go run . validate [--tz=Area/Location] [--schedule=feed.csv] ./input.txt ./airport-lookup.csv")
+	}
+	fs.Parse(args)
+
+	rest := fs.Args()
+	if len(rest) != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	inputPath, airportLookupPath := rest[0], rest[1]
+
+	var targetZone *time.Location
+	if *tz != "" {
+		loc, err := time.LoadLocation(*tz)
+		if err != nil {
+			fmt.Println("Error loading --tz timezone:", err)
+			os.Exit(1)
+		}
+		targetZone = loc
+	}
+
+	lookup, err := airport.ReadLookup(airportLookupPath)
+	if err != nil {
+		fmt.Println("Error reading airportLookUp file:", err)
+		os.Exit(1)
+	}
+
+	inputData, err := os.ReadFile(inputPath)
+	if err != nil {
+		fmt.Println("Error reading input file.")
+		os.Exit(1)
+	}
+
+	it, err := itinerary.Parse(string(inputData))
+	if err != nil {
+		fmt.Println("Error processing input data:", err)
+		os.Exit(1)
+	}
+
+	var feed schedule
+	if *schedulePath != "" {
+		feed, err = readSchedule(*schedulePath)
+		if err != nil {
+			fmt.Println("Error reading --schedule feed:", err)
+			os.Exit(1)
+		}
+	}
+
+	violations, err := validate(it, lookup, feed, targetZone)
+	if err != nil {
+		fmt.Println("Error validating itinerary:", err)
+		os.Exit(1)
+	}
+
+	if len(itinerary.Legs(it)) == 0 {
+		fmt.Println("No legs found to check: no segment carries a full departure/arrival airport and time pair.")
+		return
+	}
+	if len(violations) == 0 {
+		fmt.Println("No continuity violations found.")
+		return
+	}
+	for _, v := range violations {
+		fmt.Println(v.String())
+	}
+	os.Exit(1)
+}
+
+// validate checks every consecutive pair of legs (segments with a full
+// departure/arrival airport and time pair) for continuity: the arrival
+// airport of one leg must match the departure airport of the next, and
+// the gap between them must be at least the minimum connection time for
+// a domestic or international change, based on whether the two
+// airports' iso_country match. If feed is non-empty, each leg whose
+// text contains a flight number is also cross-checked against it.
+func validate(it *itinerary.Itinerary, lookup airport.Lookup, feed schedule, tz *time.Location) ([]violation, error) {
+	var violations []violation
+	legs := itinerary.Legs(it)
+
+	for i, leg := range legs {
+		if len(feed) > 0 {
+			if v := checkSchedule(leg, lookup, feed, tz); v != nil {
+				violations = append(violations, *v)
+			}
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := legs[i-1]
+
+		prevArrival, prevOk := resolveAirport(lookup, prev.Arrive)
+		nextDeparture, nextOk := resolveAirport(lookup, leg.Depart)
+		if prevOk && nextOk && prevArrival.IATA != nextDeparture.IATA {
+			violations = append(violations, violation{
+				line:    leg.Line,
+				message: fmt.Sprintf("arrives at %s but next leg departs from %s", prevArrival.Name, nextDeparture.Name),
+			})
+			continue // a route break makes a connection-time check meaningless
+		}
+
+		prevArr, err := itinerary.ResolveTime(*prev.ArrTime, tz)
+		if err != nil {
+			return nil, err
+		}
+		nextDep, err := itinerary.ResolveTime(*leg.DepTime, tz)
+		if err != nil {
+			return nil, err
+		}
+
+		minConnection := minConnectionDomestic
+		if prevOk && nextOk && prevArrival.ISOCountry != nextDeparture.ISOCountry {
+			minConnection = minConnectionInternational
+		}
+
+		if gap := nextDep.Sub(prevArr); gap < minConnection {
+			violations = append(violations, violation{
+				line:    leg.Line,
+				message: fmt.Sprintf("only %s between arrival and next departure, need at least %s", gap, minConnection),
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+func resolveAirport(lookup airport.Lookup, tok *itinerary.Token) (airport.Airport, bool) {
+	marker := "#"
+	if tok.Kind == itinerary.ICAO {
+		marker = "##"
+	}
+	return lookup.Resolve(tok.Value, marker)
+}
+
+// extractFlightNumber searches a leg's free-form Text tokens for a
+// flight designator matching flightNumberPattern, returning the first
+// one found.
+func extractFlightNumber(leg itinerary.Leg) (string, bool) {
+	for _, tok := range leg.Tokens {
+		if tok.Kind != itinerary.Text {
+			continue
+		}
+		if m := flightNumberPattern.FindString(tok.Value); m != "" {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+// checkSchedule verifies a leg's flight number, route and times against
+// feed. A leg with no recognizable flight number in its text can't be
+// looked up and is silently skipped, since there's nothing to match on.
+func checkSchedule(leg itinerary.Leg, lookup airport.Lookup, feed schedule, tz *time.Location) *violation {
+	flightNo, ok := extractFlightNumber(leg)
+	if !ok {
+		return nil
+	}
+
+	entry, ok := feed[flightNo]
+	if !ok {
+		return &violation{
+			line:    leg.Line,
+			message: fmt.Sprintf("flight %s has no matching row in the schedule feed", flightNo),
+		}
+	}
+
+	dep, depOk := resolveAirport(lookup, leg.Depart)
+	arr, arrOk := resolveAirport(lookup, leg.Arrive)
+	if depOk && entry.depIATA != dep.IATA {
+		return &violation{
+			line:    leg.Line,
+			message: fmt.Sprintf("flight %s is scheduled from %s, not %s", flightNo, entry.depIATA, dep.IATA),
+		}
+	}
+	if arrOk && entry.arrIATA != arr.IATA {
+		return &violation{
+			line:    leg.Line,
+			message: fmt.Sprintf("flight %s is scheduled to %s, not %s", flightNo, entry.arrIATA, arr.IATA),
+		}
+	}
+
+	depTime, err := itinerary.ResolveTime(*leg.DepTime, tz)
+	if err != nil {
+		return nil
+	}
+	arrTime, err := itinerary.ResolveTime(*leg.ArrTime, tz)
+	if err != nil {
+		return nil
+	}
+	if entry.depTime != depTime.Format("15:04") || entry.arrTime != arrTime.Format("15:04") {
+		return &violation{
+			line:    leg.Line,
+			message: fmt.Sprintf("flight %s is scheduled %s-%s, not %s-%s", flightNo, entry.depTime, entry.arrTime, depTime.Format("15:04"), arrTime.Format("15:04")),
+		}
+	}
+
+	return nil
+}
+
+func readSchedule(path string) (schedule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := make(map[string]int, len(header))
+	for i, c := range header {
+		col[strings.ToLower(strings.TrimSpace(c))] = i
+	}
+
+	var missingColumns []string
+	for _, name := range []string{"flight", "dep_iata", "arr_iata", "dep_time", "arr_time"} {
+		if _, ok := col[name]; !ok {
+			missingColumns = append(missingColumns, name)
+		}
+	}
+	if len(missingColumns) > 0 {
+		return nil, fmt.Errorf("schedule feed malformed. %s", strings.Join(missingColumns, ", "))
+	}
+
+	feed := make(schedule)
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			break
+		}
+		feed[record[col["flight"]]] = scheduleEntry{
+			flight:  record[col["flight"]],
+			depIATA: record[col["dep_iata"]],
+			arrIATA: record[col["arr_iata"]],
+			depTime: record[col["dep_time"]],
+			arrTime: record[col["arr_time"]],
+		}
+	}
+	return feed, nil
+}
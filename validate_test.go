@@ -0,0 +1,150 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Debashish-deb/Itinerary-prettifier/airport"
+	"github.com/Debashish-deb/Itinerary-prettifier/itinerary"
+)
+
+func lookupWith(airports ...airport.Airport) airport.Lookup {
+	lookup := airport.Lookup{ByIATA: make(map[string]airport.Airport), ByICAO: make(map[string]airport.Airport)}
+	for _, a := range airports {
+		lookup.ByIATA[a.IATA] = a
+	}
+	return lookup
+}
+
+func TestValidateFlagsShortDomesticConnection(t *testing.T) {
+	lookup := lookupWith(
+		airport.Airport{Name: "LAX", IATA: "LAX", ISOCountry: "US"},
+		airport.Airport{Name: "ORD", IATA: "ORD", ISOCountry: "US"},
+	)
+	it, err := itinerary.Parse(
+		"#LAX #ORD D(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T11:00Z)\n" +
+			"#ORD #JFK D(2024-03-01T11:30Z) T24(2024-03-01T11:30Z) T24(2024-03-01T14:00Z)\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	violations, err := validate(it, lookup, nil, nil)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1 (30min < 45min domestic minimum): %v", len(violations), violations)
+	}
+}
+
+func TestValidateAllowsLongerInternationalConnection(t *testing.T) {
+	lookup := lookupWith(
+		airport.Airport{Name: "LAX", IATA: "LAX", ISOCountry: "US"},
+		airport.Airport{Name: "CDG", IATA: "CDG", ISOCountry: "FR"},
+		airport.Airport{Name: "FRA", IATA: "FRA", ISOCountry: "DE"},
+	)
+	it, err := itinerary.Parse(
+		"#LAX #CDG D(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T11:00Z)\n" +
+			"#CDG #FRA D(2024-03-01T12:30Z) T24(2024-03-01T12:30Z) T24(2024-03-01T14:00Z)\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	violations, err := validate(it, lookup, nil, nil)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("got %d violations, want 0 (90min gap meets the international minimum): %v", len(violations), violations)
+	}
+}
+
+func TestValidateAssemblesLegsSplitAcrossLines(t *testing.T) {
+	lookup := lookupWith(
+		airport.Airport{Name: "LAX", IATA: "LAX", ISOCountry: "US"},
+		airport.Airport{Name: "JFK", IATA: "JFK", ISOCountry: "US"},
+	)
+	it, err := itinerary.Parse(
+		"#LAX #JFK\nD(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T12:00Z)\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	legs := itinerary.Legs(it)
+	if len(legs) != 1 {
+		t.Fatalf("got %d legs, want 1 assembled from the two lines: %v", len(legs), legs)
+	}
+
+	violations, err := validate(it, lookup, nil, nil)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("a single leg has nothing to compare against, want 0 violations, got %v", violations)
+	}
+}
+
+func TestValidateReportsNoLegsFoundSeparatelyFromNoViolations(t *testing.T) {
+	it, err := itinerary.Parse("no airports or times here\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(itinerary.Legs(it)) != 0 {
+		t.Fatalf("expected no legs in an itinerary with no airport/time tokens")
+	}
+}
+
+func TestCheckScheduleFlagsMismatchedRoute(t *testing.T) {
+	lookup := lookupWith(
+		airport.Airport{Name: "LAX", IATA: "LAX"},
+		airport.Airport{Name: "JFK", IATA: "JFK"},
+	)
+	it, err := itinerary.Parse("Flight AF007 #LAX #JFK D(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T12:00Z)\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	leg := itinerary.Legs(it)[0]
+
+	feed := schedule{"AF007": scheduleEntry{flight: "AF007", depIATA: "ORD", arrIATA: "JFK", depTime: "09:00", arrTime: "12:00"}}
+	v := checkSchedule(leg, lookup, feed, nil)
+	if v == nil {
+		t.Fatal("expected a violation for a flight scheduled from a different departure airport")
+	}
+}
+
+func TestCheckScheduleFlagsUnknownFlight(t *testing.T) {
+	lookup := lookupWith(airport.Airport{Name: "LAX", IATA: "LAX"}, airport.Airport{Name: "JFK", IATA: "JFK"})
+	it, err := itinerary.Parse("Flight ZZ999 #LAX #JFK D(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T12:00Z)\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	leg := itinerary.Legs(it)[0]
+
+	v := checkSchedule(leg, lookup, schedule{}, nil)
+	if v == nil {
+		t.Fatal("expected a violation for a flight number with no matching feed row")
+	}
+}
+
+func TestCheckScheduleSkipsLegWithNoFlightNumber(t *testing.T) {
+	lookup := lookupWith(airport.Airport{Name: "LAX", IATA: "LAX"}, airport.Airport{Name: "JFK", IATA: "JFK"})
+	it, err := itinerary.Parse("#LAX #JFK D(2024-03-01T09:00Z) T24(2024-03-01T09:00Z) T24(2024-03-01T12:00Z)\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	leg := itinerary.Legs(it)[0]
+
+	v := checkSchedule(leg, lookup, schedule{"AF007": scheduleEntry{}}, nil)
+	if v != nil {
+		t.Errorf("a leg with no recognizable flight number should be silently skipped, got %v", v)
+	}
+}
+
+func TestMinConnectionThresholds(t *testing.T) {
+	if minConnectionDomestic != 45*time.Minute {
+		t.Errorf("minConnectionDomestic = %v, want 45m", minConnectionDomestic)
+	}
+	if minConnectionInternational != 90*time.Minute {
+		t.Errorf("minConnectionInternational = %v, want 90m", minConnectionInternational)
+	}
+}